@@ -0,0 +1,20 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// rootCmd is the top-level `stripe` command that every other command is
+// registered under.
+var rootCmd = &cobra.Command{
+	Use:   "stripe",
+	Short: "A CLI to help you integrate Stripe with your application",
+}
+
+func init() {
+	rootCmd.AddCommand(newTerminalCmd().cmd)
+}
+
+// Execute runs the root command, dispatching to whichever subcommand the
+// user invoked.
+func Execute() error {
+	return rootCmd.Execute()
+}
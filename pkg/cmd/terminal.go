@@ -0,0 +1,19 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// terminalCmd is the parent of the `stripe terminal` subcommands.
+type terminalCmd struct {
+	cmd *cobra.Command
+}
+
+func newTerminalCmd() *terminalCmd {
+	tc := &terminalCmd{}
+	tc.cmd = &cobra.Command{
+		Use:   "terminal",
+		Short: "Interact with Stripe Terminal readers",
+	}
+	tc.cmd.AddCommand(newTerminalListenCmd().cmd)
+
+	return tc
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/terminal/p400"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+// terminalListenCmd implements `stripe terminal listen`, which reuses the
+// CLI's existing websocket client (the same one behind `stripe listen`)
+// filtered down to Terminal reader and payment intent events, so Terminal
+// quickstart users can observe async reader state changes without polling
+// DiscoverReaders.
+type terminalListenCmd struct {
+	cmd *cobra.Command
+
+	apiKey string
+	secret string
+}
+
+func newTerminalListenCmd() *terminalListenCmd {
+	tlc := &terminalListenCmd{}
+	tlc.cmd = &cobra.Command{
+		Use:   "listen",
+		Args:  cobra.NoArgs,
+		Short: "Listen for Terminal reader and payment intent events",
+		Long:  "Listen for `terminal.reader.*`, `payment_intent.succeeded`, `payment_intent.payment_failed` and `payment_intent.requires_action` events over the CLI's websocket session, verifying each webhook's signature before dispatching it.",
+		RunE:  tlc.runTerminalListenCmd,
+	}
+	tlc.cmd.Flags().StringVar(&tlc.apiKey, "api-key", "", "the Stripe API key to authenticate the websocket session with")
+	tlc.cmd.Flags().StringVar(&tlc.secret, "secret", "", "the webhook signing secret used to verify incoming events")
+
+	return tlc
+}
+
+func (tlc *terminalListenCmd) runTerminalListenCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	src := newWebsocketEventSource(tlc.apiKey)
+
+	return p400.Listen(ctx, src, tlc.secret, func(ctx context.Context, event p400.Event) error {
+		fmt.Printf("%s %s\n", event.Type, event.ID)
+		return nil
+	})
+}
+
+// websocketEventSource adapts the CLI's websocket.Client to
+// p400.EventSource.
+type websocketEventSource struct {
+	apiKey string
+}
+
+func newWebsocketEventSource(apiKey string) *websocketEventSource {
+	return &websocketEventSource{apiKey: apiKey}
+}
+
+func (w *websocketEventSource) Events(ctx context.Context) (<-chan p400.RawEvent, error) {
+	events := make(chan p400.RawEvent)
+
+	client := websocket.NewClient(
+		websocket.DefaultURL,
+		w.apiKey,
+		"",
+		&websocket.Config{
+			EventHandler: websocket.EventHandlerFunc(func(msg websocket.StripeEvent) {
+				if msg.WebhookEvent == nil {
+					return
+				}
+
+				select {
+				case <-ctx.Done():
+				case events <- p400.RawEvent{
+					Payload:   []byte(msg.WebhookEvent.EventPayload),
+					Signature: msg.WebhookEvent.HTTPHeaders["Stripe-Signature"],
+				}:
+				}
+			}),
+		},
+	)
+
+	go func() {
+		defer close(events)
+
+		client.Run(ctx)
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,71 @@
+// Package terminal dispatches Terminal reader operations to a
+// device-specific driver, so the CLI's quickstart can run against any
+// Terminal reader on the account rather than only P400 hardware.
+package terminal
+
+import (
+	"context"
+	"fmt"
+)
+
+// DiscoveredReader is the device-agnostic shape a driver's Discover method
+// returns, carrying just enough information to pick a driver for the
+// reader and connect to it.
+type DiscoveredReader struct {
+	ID         string
+	DeviceType string
+	Status     string
+	IPAddress  string
+}
+
+// Reader is the interface every Terminal reader driver implements,
+// whether the hardware talks to Stripe via the legacy P400 RPC session
+// flow or the server-driven API used by WisePOS E and BBPOS WisePad 3.
+type Reader interface {
+	// Discover lists the readers of this driver's device type registered
+	// on the account.
+	Discover(ctx context.Context) ([]DiscoveredReader, error)
+	// Connect establishes a session with the reader identified by readerID.
+	Connect(ctx context.Context, readerID string) error
+	// CollectPaymentMethod prompts the connected reader to collect a
+	// payment method for paymentIntentID.
+	CollectPaymentMethod(ctx context.Context, paymentIntentID string) error
+	// ProcessPayment processes the collected payment method against
+	// paymentIntentID.
+	ProcessPayment(ctx context.Context, paymentIntentID string) error
+	// Cancel cancels the reader's current action.
+	Cancel(ctx context.Context) error
+	// Register registers a new reader to the account using regCode,
+	// returning the reader's IP address.
+	Register(ctx context.Context, regCode string) (string, error)
+}
+
+// Factory builds a Reader driver authenticated with apiKey. baseURL may be
+// empty, in which case the driver falls back to the Stripe API's default
+// base URL. Each driver package registers its own Factory under the
+// device_type value DiscoverReaders reports for its hardware.
+type Factory func(apiKey, baseURL string) Reader
+
+var drivers = map[string]Factory{}
+
+// Register makes a driver available under deviceType for NewDriver to
+// dispatch to. It's meant to be called from a driver package's init, e.g.
+// the p400 and smartreader packages register themselves as soon as they're
+// imported for their side effect.
+func Register(deviceType string, factory Factory) {
+	drivers[deviceType] = factory
+}
+
+// NewDriver returns the Reader driver registered for deviceType, the value
+// reported in the `device_type` field of a DiscoveredReader, authenticated
+// with apiKey. Callers must blank-import the driver packages they want
+// available (e.g. `_ "github.com/stripe/stripe-cli/pkg/terminal/p400"`)
+// before calling NewDriver.
+func NewDriver(deviceType, apiKey, baseURL string) (Reader, error) {
+	factory, ok := drivers[deviceType]
+	if !ok {
+		return nil, fmt.Errorf("terminal: no driver registered for device type %q", deviceType)
+	}
+
+	return factory(apiKey, baseURL), nil
+}
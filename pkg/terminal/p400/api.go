@@ -0,0 +1,265 @@
+package p400
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+// StripeAPI is the set of Stripe API calls the p400 package needs to drive a
+// Terminal quickstart session. It exists so the package-level functions
+// (DiscoverReaders, StartNewRPCSession, ...) can be exercised in unit tests
+// against a fake implementation instead of the real Stripe API.
+type StripeAPI interface {
+	DiscoverReaders(ctx context.Context, opts ReaderListOptions) (*readersResponse, error)
+	StartNewRPCSession(ctx context.Context, tsCtx TerminalSessionContext) (*RPCSessionResult, error)
+	GetNewConnectionToken(ctx context.Context) (*ConnectionTokenResult, error)
+	CreatePaymentIntent(ctx context.Context, tsCtx TerminalSessionContext) (*PaymentIntentResult, error)
+	CapturePaymentIntent(ctx context.Context, paymentIntentID string) (*APIResponse, error)
+	RegisterReader(ctx context.Context, regcode string) (*RegisterReaderResult, error)
+}
+
+// realStripeAPI is the StripeAPI implementation backed by the real Stripe
+// API. The RPC session endpoint is called directly over httpClient rather
+// than through client, since it authenticates with a bearer token instead
+// of a Stripe API key.
+type realStripeAPI struct {
+	client     *stripe.Client
+	httpClient *http.Client
+	baseURL    string
+}
+
+// newRealStripeAPI builds the StripeAPI implementation used whenever a
+// TerminalSessionContext doesn't inject one of its own. baseURL defaults to
+// stripe.DefaultAPIBaseURL, letting tests point it at an httptest.Server.
+func newRealStripeAPI(apiKey, baseURL string) *realStripeAPI {
+	if baseURL == "" {
+		baseURL = stripe.DefaultAPIBaseURL
+	}
+
+	parsedBaseURL, err := url.Parse(baseURL)
+	if err != nil {
+		parsedBaseURL = &url.URL{}
+	}
+
+	return &realStripeAPI{
+		client: &stripe.Client{
+			BaseURL: parsedBaseURL,
+			APIKey:  apiKey,
+			Verbose: false,
+		},
+		httpClient: &http.Client{},
+		baseURL:    baseURL,
+	}
+}
+
+// readAndDecode reads res's body in full so it can be captured on the
+// APIResponse as RawBody, then decodes it as JSON into v.
+func readAndDecode(res *http.Response, v interface{}) (*APIResponse, error) {
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, err
+	}
+
+	return newAPIResponse(res, body), nil
+}
+
+func (r *realStripeAPI) DiscoverReaders(ctx context.Context, opts ReaderListOptions) (*readersResponse, error) {
+	path := stripeTerminalReadersPath
+
+	if query := opts.toQuery(); len(query) > 0 {
+		path = fmt.Sprintf("%s?%s", path, query.Encode())
+	}
+
+	res, err := PerformWithRetry(ctx, func(ctx context.Context) (*http.Response, error) {
+		return r.client.PerformRequest(ctx, http.MethodGet, path, "", nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		defer res.Body.Close()
+		return nil, decodeAPIError(res)
+	}
+
+	var result readersResponse
+	lastResponse, err := readAndDecode(res, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range result.Data {
+		result.Data[i].SetLastResponse(lastResponse)
+	}
+
+	return &result, nil
+}
+
+func (r *realStripeAPI) StartNewRPCSession(ctx context.Context, tsCtx TerminalSessionContext) (*RPCSessionResult, error) {
+	rpcSessionURL := fmt.Sprintf("%s%s", r.baseURL, rpcSessionPath)
+
+	data := url.Values{}
+	data.Set("pos_device_info[device_class]", tsCtx.DeviceInfo.DeviceClass)
+	data.Set("pos_device_info[device_uuid]", tsCtx.DeviceInfo.DeviceUUID)
+	data.Set("pos_device_info[host_os_version]", tsCtx.DeviceInfo.HostOSVersion)
+	data.Set("pos_device_info[hardware_model][pos_info][description]", tsCtx.DeviceInfo.HardwareModel.POSInfo.Description)
+	data.Set("pos_device_info[app_model][app_id]", tsCtx.DeviceInfo.AppModel.AppID)
+	data.Set("pos_device_info[app_model][app_version]", tsCtx.DeviceInfo.AppModel.AppVersion)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcSessionURL, bytes.NewBufferString(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %v", tsCtx.PstToken))
+
+	res, err := r.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		defer res.Body.Close()
+		return nil, decodeAPIError(res)
+	}
+
+	var result RPCSessionResult
+	lastResponse, err := readAndDecode(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	result.SetLastResponse(lastResponse)
+
+	return &result, nil
+}
+
+func (r *realStripeAPI) GetNewConnectionToken(ctx context.Context) (*ConnectionTokenResult, error) {
+	res, err := r.client.PerformRequest(ctx, http.MethodPost, stripeTerminalConnectionTokensPath, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		defer res.Body.Close()
+		return nil, decodeAPIError(res)
+	}
+
+	var result ConnectionTokenResult
+	lastResponse, err := readAndDecode(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	result.SetLastResponse(lastResponse)
+
+	return &result, nil
+}
+
+func (r *realStripeAPI) CreatePaymentIntent(ctx context.Context, tsCtx TerminalSessionContext) (*PaymentIntentResult, error) {
+	data := url.Values{}
+	data.Set("amount", strconv.Itoa(tsCtx.Amount))
+	data.Set("currency", tsCtx.Currency)
+	data.Set("payment_method_types[]", "card_present")
+	data.Set("capture_method", "manual")
+	data.Set("description", "Stripe CLI Test Payment")
+
+	idempotencyKey := uuid.NewString()
+
+	res, err := PerformWithRetry(ctx, func(ctx context.Context) (*http.Response, error) {
+		return r.client.PerformRequest(ctx, http.MethodPost, stripeCreatePaymentIntentPath, data.Encode(), map[string]string{
+			"Idempotency-Key": idempotencyKey,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		defer res.Body.Close()
+		return nil, decodeAPIError(res)
+	}
+
+	var result PaymentIntentResult
+	lastResponse, err := readAndDecode(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	result.SetLastResponse(lastResponse)
+
+	return &result, nil
+}
+
+func (r *realStripeAPI) CapturePaymentIntent(ctx context.Context, paymentIntentID string) (*APIResponse, error) {
+	capturePath := fmt.Sprintf(stripeCapturePaymentIntentPath, paymentIntentID)
+	idempotencyKey := uuid.NewString()
+
+	res, err := PerformWithRetry(ctx, func(ctx context.Context) (*http.Response, error) {
+		return r.client.PerformRequest(ctx, http.MethodPost, capturePath, "", map[string]string{
+			"Idempotency-Key": idempotencyKey,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		apiErr, err := tryDecodeAPIError(res)
+		if err != nil {
+			return nil, err
+		}
+
+		if apiErr != nil {
+			return nil, apiErr
+		}
+
+		return nil, ErrCapturePaymentIntentFailed
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAPIResponse(res, body), nil
+}
+
+func (r *realStripeAPI) RegisterReader(ctx context.Context, regcode string) (*RegisterReaderResult, error) {
+	data := url.Values{}
+	data.Set("registration_code", regcode)
+
+	res, err := r.client.PerformRequest(ctx, http.MethodPost, stripeTerminalRegisterPath, data.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		defer res.Body.Close()
+		return nil, decodeAPIError(res)
+	}
+
+	var result RegisterReaderResult
+	lastResponse, err := readAndDecode(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	result.SetLastResponse(lastResponse)
+
+	return &result, nil
+}
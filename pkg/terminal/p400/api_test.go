@@ -0,0 +1,154 @@
+package p400
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestMain disables PerformWithRetry's backoff delay for the whole package,
+// so tests that exercise retryable status codes don't spend real wall-clock
+// time sleeping between attempts.
+func TestMain(m *testing.M) {
+	retrySleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	os.Exit(m.Run())
+}
+
+func TestDiscoverReaders_RealAPI(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantErr    bool
+		wantAPIErr bool
+		wantCount  int
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			body:       `{"object":"list","has_more":false,"data":[{"id":"tmr_123","device_type":"verifone_P400","status":"online"}]}`,
+			wantCount:  1,
+		},
+		{
+			name:       "non-2xx error envelope",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"error":{"type":"invalid_request_error","code":"api_key_invalid","message":"Invalid API Key provided"}}`,
+			wantErr:    true,
+			wantAPIErr: true,
+		},
+		{
+			name:       "non-2xx malformed body",
+			statusCode: http.StatusInternalServerError,
+			body:       `not json`,
+			wantErr:    true,
+			wantAPIErr: false,
+		},
+		{
+			name:       "2xx decode failure",
+			statusCode: http.StatusOK,
+			body:       `not json`,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Request-Id", "req_123")
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			tsCtx := TerminalSessionContext{APIKey: "sk_test_123", BaseURL: server.URL}
+
+			it := DiscoverReaders(context.Background(), tsCtx, ReaderListOptions{})
+
+			var readers []Reader
+			for it.Next() {
+				readers = append(readers, it.Reader())
+			}
+
+			err := it.Err()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Err() = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				var apiErr *APIError
+				if tt.wantAPIErr != errors.As(err, &apiErr) {
+					t.Fatalf("errors.As(err, *APIError) = %v, want %v (err: %v)", !tt.wantAPIErr, tt.wantAPIErr, err)
+				}
+
+				return
+			}
+
+			if len(readers) != tt.wantCount {
+				t.Fatalf("got %d readers, want %d", len(readers), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestCreatePaymentIntent_RealAPI(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantErr    bool
+		wantID     string
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			body:       `{"id":"pi_123"}`,
+			wantID:     "pi_123",
+		},
+		{
+			name:       "declined",
+			statusCode: http.StatusPaymentRequired,
+			body:       `{"error":{"type":"card_error","code":"card_declined","message":"Your card was declined."}}`,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Request-Id", "req_456")
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			tsCtx := TerminalSessionContext{
+				APIKey:   "sk_test_123",
+				BaseURL:  server.URL,
+				Amount:   1000,
+				Currency: "usd",
+			}
+
+			result, err := CreatePaymentIntent(tsCtx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreatePaymentIntent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if result.ID != tt.wantID {
+				t.Fatalf("got ID %q, want %q", result.ID, tt.wantID)
+			}
+
+			if result.LastResponse == nil || result.LastResponse.RequestID != "req_456" {
+				t.Fatalf("expected LastResponse.RequestID to be populated from the Request-Id header, got %+v", result.LastResponse)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package p400
+
+const (
+	stripeTerminalReadersPath          = "/v1/terminal/readers"
+	rpcSessionPath                     = "/v1/terminal/readers/rpc_session"
+	stripeTerminalConnectionTokensPath = "/v1/terminal/connection_tokens"
+	stripeCreatePaymentIntentPath      = "/v1/payment_intents"
+	stripeCapturePaymentIntentPath     = "/v1/payment_intents/%s/capture"
+	stripeTerminalRegisterPath         = "/v1/terminal/readers/register"
+)
@@ -0,0 +1,48 @@
+package p400
+
+// DeviceInfo describes the physical P400 reader and the host application
+// that is requesting a new RPC session with it.
+type DeviceInfo struct {
+	DeviceClass   string
+	DeviceUUID    string
+	HostOSVersion string
+	HardwareModel struct {
+		POSInfo struct {
+			Description string
+		}
+	}
+	AppModel struct {
+		AppID      string
+		AppVersion string
+	}
+}
+
+// TerminalSessionContext carries the per-invocation state needed to drive a
+// P400 Terminal quickstart session: the Stripe API key, details about the
+// connected reader, and the payment being collected.
+//
+// API and BaseURL are optional injection points. When API is nil, each
+// function builds a real Stripe-backed client from APIKey and BaseURL; tests
+// can set API to a fake to exercise error paths without making network
+// calls.
+type TerminalSessionContext struct {
+	APIKey          string
+	BaseURL         string
+	API             StripeAPI
+	DeviceInfo      DeviceInfo
+	PstToken        string
+	PaymentIntentID string
+	Amount          int
+	Currency        string
+}
+
+// api returns the StripeAPI implementation this context should use. If one
+// wasn't injected, it constructs a new real Stripe-backed client on every
+// call; nothing is cached.
+func (tsCtx TerminalSessionContext) api() StripeAPI {
+	if tsCtx.API != nil {
+		return tsCtx.API
+	}
+
+	return newRealStripeAPI(tsCtx.APIKey, tsCtx.BaseURL)
+}
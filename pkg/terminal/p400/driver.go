@@ -0,0 +1,114 @@
+package p400
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stripe/stripe-cli/pkg/terminal"
+)
+
+// deviceType is the device_type value Stripe reports for P400 readers.
+const deviceType = "verifone_P400"
+
+func init() {
+	terminal.Register(deviceType, func(apiKey, baseURL string) terminal.Reader {
+		return NewDriver(TerminalSessionContext{APIKey: apiKey, BaseURL: baseURL})
+	})
+}
+
+// Driver adapts the package-level P400 RPC session functions to the
+// device-agnostic terminal.Reader interface so the CLI can dispatch to it
+// alongside other reader drivers. It's registered under deviceType so
+// terminal.NewDriver can find it.
+type Driver struct {
+	tsCtx TerminalSessionContext
+
+	readerID string
+}
+
+// NewDriver returns a P400 Driver that authenticates with tsCtx.
+func NewDriver(tsCtx TerminalSessionContext) *Driver {
+	return &Driver{tsCtx: tsCtx}
+}
+
+// Discover lists the P400 readers registered on the account.
+func (d *Driver) Discover(ctx context.Context) ([]terminal.DiscoveredReader, error) {
+	it := DiscoverReaders(ctx, d.tsCtx, ReaderListOptions{DeviceType: deviceType})
+
+	var readers []terminal.DiscoveredReader
+	for it.Next() {
+		r := it.Reader()
+		readers = append(readers, terminal.DiscoveredReader{
+			ID:         r.ID,
+			DeviceType: r.DeviceType,
+			Status:     r.Status,
+			IPAddress:  r.IPAddress,
+		})
+	}
+
+	return readers, it.Err()
+}
+
+// Connect starts a new RPC session, records readerID as the reader
+// subsequent calls target, and stores the resulting session token for
+// subsequent calls. readerID itself is not sent with the session request.
+func (d *Driver) Connect(ctx context.Context, readerID string) error {
+	result, err := StartNewRPCSession(d.tsCtx)
+	if err != nil {
+		return err
+	}
+
+	d.readerID = readerID
+	d.tsCtx.PstToken = result.SDKRPCSessionToken
+
+	return nil
+}
+
+// CollectPaymentMethod is not supported through the device-agnostic
+// terminal.Reader interface: on the P400, collecting a payment method is
+// driven by the native SDK over the RPC session Connect establishes, not by
+// an HTTP call this adapter can make on the caller's behalf. Drive
+// collection through the native SDK directly, then call ProcessPayment.
+func (d *Driver) CollectPaymentMethod(ctx context.Context, paymentIntentID string) error {
+	if d.readerID == "" {
+		return fmt.Errorf("p400: Connect must be called before CollectPaymentMethod")
+	}
+
+	return fmt.Errorf("p400: collecting a payment method is driven by the native SDK over the RPC session, not by this adapter")
+}
+
+// ProcessPayment captures paymentIntentID, assuming a payment method has
+// already been attached to it by the native SDK over the RPC session this
+// driver established in Connect. Since this adapter has no way to confirm
+// that collection actually happened, callers driving the P400 purely
+// through the terminal.Reader interface will see this capture fail with a
+// 400 from Stripe if it hasn't.
+func (d *Driver) ProcessPayment(ctx context.Context, paymentIntentID string) error {
+	if d.readerID == "" {
+		return fmt.Errorf("p400: Connect must be called before ProcessPayment")
+	}
+
+	d.tsCtx.PaymentIntentID = paymentIntentID
+	_, err := CapturePaymentIntent(d.tsCtx)
+
+	return err
+}
+
+// Cancel is not supported by the P400's RPC session flow.
+func (d *Driver) Cancel(ctx context.Context) error {
+	if d.readerID == "" {
+		return fmt.Errorf("p400: Connect must be called before Cancel")
+	}
+
+	return fmt.Errorf("p400: canceling an in-progress action is not supported by the P400 driver")
+}
+
+// Register registers a new P400 reader to the account using regCode.
+func (d *Driver) Register(ctx context.Context, regCode string) (string, error) {
+	result, err := RegisterReader(regCode, d.tsCtx)
+	if err != nil {
+		return "", err
+	}
+
+	return result.IPAddress, nil
+}
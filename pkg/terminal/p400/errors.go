@@ -0,0 +1,74 @@
+package p400
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrCapturePaymentIntentFailed is returned by CapturePaymentIntent when the
+// Stripe API responds with a status of 400 or above and no more specific APIError
+// could be decoded from the response body.
+var ErrCapturePaymentIntentFailed = errors.New("p400: failed to capture payment intent")
+
+// APIError decodes Stripe's standard error envelope
+// (https://stripe.com/docs/api/errors), returned when a call made by this
+// package receives a StatusCode >= 400 whose body is a well-formed error
+// envelope. Other error bodies produce a generic error carrying the status
+// code instead.
+type APIError struct {
+	Type      string `json:"type"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Param     string `json:"param"`
+	RequestID string `json:"request_id"`
+	DocURL    string `json:"doc_url"`
+
+	// LastResponse is the API response the error was decoded from.
+	LastResponse *APIResponse
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("p400: stripe error (request %s): %s: %s", e.RequestID, e.Code, e.Message)
+}
+
+type apiErrorEnvelope struct {
+	Error APIError `json:"error"`
+}
+
+// tryDecodeAPIError reads res's body and attempts to decode it as a Stripe
+// error envelope, returning nil if the body isn't a well-formed one.
+func tryDecodeAPIError(res *http.Response) (*APIError, error) {
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope apiErrorEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Error.Message == "" {
+		return nil, nil
+	}
+
+	envelope.Error.LastResponse = newAPIResponse(res, body)
+
+	return &envelope.Error, nil
+}
+
+// decodeAPIError reads res's body and decodes it as a Stripe error envelope.
+// If the body isn't a well-formed envelope, it falls back to a generic
+// error carrying the status code, so callers never see a bare JSON decode
+// failure in place of the real problem.
+func decodeAPIError(res *http.Response) error {
+	apiErr, err := tryDecodeAPIError(res)
+	if err != nil {
+		return fmt.Errorf("p400: request failed with status %d and the response body couldn't be read: %w", res.StatusCode, err)
+	}
+
+	if apiErr == nil {
+		return fmt.Errorf("p400: request failed with status %d", res.StatusCode)
+	}
+
+	return apiErr
+}
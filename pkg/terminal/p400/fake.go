@@ -0,0 +1,41 @@
+package p400
+
+import "context"
+
+// FakeStripeAPI is a StripeAPI implementation for tests that stubs out
+// individual calls without spinning up an httptest.Server for every
+// scenario. Set only the funcs a given test exercises; calling an unset func
+// panics with a nil pointer dereference, which is deliberate: it means the
+// test reached a call it didn't expect to make.
+type FakeStripeAPI struct {
+	DiscoverReadersFunc       func(ctx context.Context, opts ReaderListOptions) (*readersResponse, error)
+	StartNewRPCSessionFunc    func(ctx context.Context, tsCtx TerminalSessionContext) (*RPCSessionResult, error)
+	GetNewConnectionTokenFunc func(ctx context.Context) (*ConnectionTokenResult, error)
+	CreatePaymentIntentFunc   func(ctx context.Context, tsCtx TerminalSessionContext) (*PaymentIntentResult, error)
+	CapturePaymentIntentFunc  func(ctx context.Context, paymentIntentID string) (*APIResponse, error)
+	RegisterReaderFunc        func(ctx context.Context, regcode string) (*RegisterReaderResult, error)
+}
+
+func (f *FakeStripeAPI) DiscoverReaders(ctx context.Context, opts ReaderListOptions) (*readersResponse, error) {
+	return f.DiscoverReadersFunc(ctx, opts)
+}
+
+func (f *FakeStripeAPI) StartNewRPCSession(ctx context.Context, tsCtx TerminalSessionContext) (*RPCSessionResult, error) {
+	return f.StartNewRPCSessionFunc(ctx, tsCtx)
+}
+
+func (f *FakeStripeAPI) GetNewConnectionToken(ctx context.Context) (*ConnectionTokenResult, error) {
+	return f.GetNewConnectionTokenFunc(ctx)
+}
+
+func (f *FakeStripeAPI) CreatePaymentIntent(ctx context.Context, tsCtx TerminalSessionContext) (*PaymentIntentResult, error) {
+	return f.CreatePaymentIntentFunc(ctx, tsCtx)
+}
+
+func (f *FakeStripeAPI) CapturePaymentIntent(ctx context.Context, paymentIntentID string) (*APIResponse, error) {
+	return f.CapturePaymentIntentFunc(ctx, paymentIntentID)
+}
+
+func (f *FakeStripeAPI) RegisterReader(ctx context.Context, regcode string) (*RegisterReaderResult, error) {
+	return f.RegisterReaderFunc(ctx, regcode)
+}
@@ -0,0 +1,165 @@
+package p400
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSignatureTolerance is how old a webhook's t= timestamp may be
+// before VerifyEventSignature rejects it as stale.
+const DefaultSignatureTolerance = 5 * time.Minute
+
+// terminalReaderEventPrefix matches the whole terminal.reader.* event
+// family, rather than enumerating each individual reader event type.
+const terminalReaderEventPrefix = "terminal.reader."
+
+// paymentIntentEventTypes are the payment_intent event types Listen
+// dispatches to handlers alongside the terminal.reader.* family; anything
+// else received over src is silently ignored.
+var paymentIntentEventTypes = map[string]bool{
+	"payment_intent.succeeded":       true,
+	"payment_intent.payment_failed":  true,
+	"payment_intent.requires_action": true,
+}
+
+// isTerminalEventType reports whether eventType is one Listen dispatches to
+// handlers: the whole terminal.reader.* family, plus the specific
+// payment_intent event types relevant to a Terminal payment's lifecycle.
+func isTerminalEventType(eventType string) bool {
+	return strings.HasPrefix(eventType, terminalReaderEventPrefix) || paymentIntentEventTypes[eventType]
+}
+
+// Event is a decoded Terminal-related webhook event.
+type Event struct {
+	ID      string          `json:"id"`
+	Type    string          `json:"type"`
+	Created int64           `json:"created"`
+	Data    EventObjectData `json:"data"`
+}
+
+// EventObjectData wraps the raw object payload of an Event so callers can
+// decode it into the concrete type matching Type (e.g. Reader or a
+// payment intent struct).
+type EventObjectData struct {
+	Object json.RawMessage `json:"object"`
+}
+
+// EventHandler is invoked for every Terminal event Listen receives whose
+// signature verifies and whose type is one this package understands.
+type EventHandler func(ctx context.Context, event Event) error
+
+// RawEvent is a single webhook delivery as received over an EventSource,
+// before signature verification or JSON decoding.
+type RawEvent struct {
+	Payload   []byte
+	Signature string
+}
+
+// EventSource delivers raw webhook deliveries to Listen. The CLI's
+// `stripe listen` websocket/tunnel session satisfies this interface in
+// production; tests can supply their own.
+type EventSource interface {
+	Events(ctx context.Context) (<-chan RawEvent, error)
+}
+
+// Listen subscribes to Terminal-related webhook events (reader state
+// changes and payment intent lifecycle events) delivered over src. Every
+// event's signature is verified against secret before it's decoded and
+// dispatched to handler; events that fail verification or aren't one of
+// the types this package understands are dropped.
+//
+// Listen blocks until ctx is canceled, src's channel closes, or handler
+// returns an error, which Listen then returns.
+func Listen(ctx context.Context, src EventSource, secret string, handler EventHandler) error {
+	events, err := src.Events(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case raw, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			if err := VerifyEventSignature(raw.Payload, raw.Signature, secret, DefaultSignatureTolerance); err != nil {
+				continue
+			}
+
+			var event Event
+			if err := json.Unmarshal(raw.Payload, &event); err != nil {
+				continue
+			}
+
+			if !isTerminalEventType(event.Type) {
+				continue
+			}
+
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// VerifyEventSignature verifies a Stripe-Signature header value against
+// payload using secret, mirroring the scheme documented at
+// https://stripe.com/docs/webhooks/signatures: the header carries a `t=`
+// timestamp and one or more `v1=` HMAC-SHA256 signatures of
+// "{t}.{payload}", and the event is rejected if no v1 signature matches or
+// if the timestamp is older than tolerance.
+func VerifyEventSignature(payload []byte, sigHeader, secret string, tolerance time.Duration) error {
+	var timestamp int64
+
+	var signatures []string
+
+	for _, part := range strings.Split(sigHeader, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		switch kv[0] {
+		case "t":
+			ts, err := strconv.ParseInt(kv[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("p400: invalid timestamp in Stripe-Signature header: %w", err)
+			}
+
+			timestamp = ts
+		case "v1":
+			signatures = append(signatures, kv[1])
+		}
+	}
+
+	if timestamp == 0 || len(signatures) == 0 {
+		return fmt.Errorf("p400: malformed Stripe-Signature header")
+	}
+
+	age := time.Since(time.Unix(timestamp, 0))
+	if age > tolerance {
+		return fmt.Errorf("p400: webhook timestamp is too old: %s", age)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.%s", timestamp, payload)))
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	for _, sig := range signatures {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("p400: no matching signature found")
+}
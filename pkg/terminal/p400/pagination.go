@@ -0,0 +1,131 @@
+package p400
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// ReaderListOptions filters and paginates a call to DiscoverReaders.
+// StartingAfter is set internally by ReaderIterator as it pages through
+// results; callers only need to set the filters below.
+type ReaderListOptions struct {
+	Limit      int
+	Location   string
+	Status     string
+	DeviceType string
+
+	StartingAfter string
+}
+
+// toQuery encodes the options as the query parameters DiscoverReaders sends
+// to the Stripe API.
+func (o ReaderListOptions) toQuery() url.Values {
+	query := url.Values{}
+
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+
+	if o.Location != "" {
+		query.Set("location", o.Location)
+	}
+
+	if o.Status != "" {
+		query.Set("status", o.Status)
+	}
+
+	if o.DeviceType != "" {
+		query.Set("device_type", o.DeviceType)
+	}
+
+	if o.StartingAfter != "" {
+		query.Set("starting_after", o.StartingAfter)
+	}
+
+	return query
+}
+
+// ReaderIterator iterates over a paginated list of Terminal readers,
+// transparently following `starting_after` pagination while the API
+// reports has_more, similar to the list iterators in stripe-go.
+type ReaderIterator struct {
+	ctx   context.Context
+	tsCtx TerminalSessionContext
+	opts  ReaderListOptions
+
+	page    []Reader
+	idx     int
+	hasMore bool
+	started bool
+
+	cur Reader
+	err error
+}
+
+// DiscoverReaders calls the Stripe API to list the Terminal readers
+// registered on the account matching opts (readers of every device type
+// unless opts.DeviceType is set), returning an iterator that pages through
+// every matching reader. Call Err after Next returns false to check whether
+// iteration stopped because of an error rather than reaching the end.
+func DiscoverReaders(ctx context.Context, tsCtx TerminalSessionContext, opts ReaderListOptions) *ReaderIterator {
+	return &ReaderIterator{ctx: ctx, tsCtx: tsCtx, opts: opts}
+}
+
+// Next advances the iterator, fetching the next page from the API if the
+// current page has been exhausted. It returns false once iteration is done
+// or an error occurred; check Err to tell the two apart.
+func (it *ReaderIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.idx >= len(it.page) {
+		if it.started && !it.hasMore {
+			return false
+		}
+
+		if err := it.fetchNextPage(); err != nil {
+			it.err = err
+			return false
+		}
+
+		if len(it.page) == 0 {
+			return false
+		}
+	}
+
+	it.cur = it.page[it.idx]
+	it.idx++
+
+	return true
+}
+
+func (it *ReaderIterator) fetchNextPage() error {
+	if len(it.page) > 0 {
+		it.opts.StartingAfter = it.page[len(it.page)-1].ID
+	}
+
+	result, err := it.tsCtx.api().DiscoverReaders(it.ctx, it.opts)
+	if err != nil {
+		return err
+	}
+
+	it.page = result.Data
+	it.idx = 0
+	it.hasMore = result.HasMore
+	it.started = true
+
+	return nil
+}
+
+// Reader returns the reader at the iterator's current position. Call it
+// after a call to Next that returned true.
+func (it *ReaderIterator) Reader() Reader {
+	return it.cur
+}
+
+// Err returns the error, if any, that stopped iteration.
+func (it *ReaderIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,40 @@
+package p400
+
+import "net/http"
+
+// APIResponse contains metadata about an API call made to Stripe, mirroring
+// the LastResponse pattern in stripe-go. It lets callers correlate a p400
+// action with the matching entry in the Stripe Dashboard's request logs
+// when debugging a Terminal flow or filing a support ticket.
+type APIResponse struct {
+	RequestID  string
+	StatusCode int
+	Status     string
+	Header     http.Header
+	RawBody    []byte
+}
+
+// APIResource is embedded in every struct this package returns from a
+// Stripe API call, exposing the APIResponse that produced it.
+type APIResource struct {
+	LastResponse *APIResponse
+}
+
+// SetLastResponse attaches resp as the resource's LastResponse. It's used
+// internally right after decoding a response body into a resource.
+func (r *APIResource) SetLastResponse(resp *APIResponse) {
+	r.LastResponse = resp
+}
+
+// newAPIResponse builds an APIResponse from an *http.Response and its
+// already-read body, capturing the Request-Id header Stripe sends back on
+// every call.
+func newAPIResponse(res *http.Response, rawBody []byte) *APIResponse {
+	return &APIResponse{
+		RequestID:  res.Header.Get("Request-Id"),
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		Header:     res.Header,
+		RawBody:    rawBody,
+	}
+}
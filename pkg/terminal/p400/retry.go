@@ -0,0 +1,104 @@
+package p400
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	retryBaseDelay   = 500 * time.Millisecond
+	retryMultiplier  = 2
+	retryMaxAttempts = 5
+)
+
+// retrySleep waits out d, honoring ctx cancellation. It's a package variable
+// so tests can stub it out and run the retry loop without incurring real
+// backoff delays.
+var retrySleep = func(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// PerformWithRetry calls attempt, retrying idempotent requests that come
+// back with a 429 or 5xx response or that fail with a transport error
+// (attempt returning a non-nil error), using exponential backoff with jitter
+// (500ms base, doubling, up to 5 attempts total) and honoring a Retry-After
+// response header when the server sends one. attempt should perform a
+// single try of the request and return its *http.Response.
+//
+// On success, or once retries are exhausted, PerformWithRetry returns
+// attempt's own result untouched: callers are expected to apply their usual
+// StatusCode >= 400 handling to the returned response themselves, so a
+// non-2xx body that survives every retry still gets decoded as a proper
+// APIError instead of being replaced with a synthetic one here.
+//
+// Callers making a POST should thread an idempotency key through attempt
+// (see CreatePaymentIntent and CapturePaymentIntent) before relying on
+// PerformWithRetry, since retrying a non-idempotent request without one
+// risks double-submitting it.
+func PerformWithRetry(ctx context.Context, attempt func(ctx context.Context) (*http.Response, error)) (*http.Response, error) {
+	delay := retryBaseDelay
+
+	for i := 0; i < retryMaxAttempts; i++ {
+		res, err := attempt(ctx)
+		if err == nil && !isRetryableStatus(res) {
+			return res, nil
+		}
+
+		if i == retryMaxAttempts-1 {
+			return res, err
+		}
+
+		wait := retryAfterDelay(res)
+		if wait == 0 {
+			wait = withJitter(delay)
+			delay *= retryMultiplier
+		}
+
+		if res != nil {
+			res.Body.Close()
+		}
+
+		if err := retrySleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+
+	// Unreachable: the loop above always returns by its last iteration.
+	return nil, nil
+}
+
+func isRetryableStatus(res *http.Response) bool {
+	if res == nil {
+		return true
+	}
+
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+}
+
+func retryAfterDelay(res *http.Response) time.Duration {
+	if res == nil {
+		return 0
+	}
+
+	secs, err := strconv.Atoi(res.Header.Get("Retry-After"))
+	if err != nil || secs <= 0 {
+		return 0
+	}
+
+	return time.Duration(secs) * time.Second
+}
+
+// withJitter returns a random duration in [d/2, d), so concurrent retries
+// from multiple clients don't all land on the Stripe API at the same
+// instant.
+func withJitter(d time.Duration) time.Duration {
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)))
+}
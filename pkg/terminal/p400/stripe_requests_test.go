@@ -0,0 +1,115 @@
+package p400
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStartNewRPCSession_WithFake(t *testing.T) {
+	tests := []struct {
+		name      string
+		fakeFunc  func(ctx context.Context, tsCtx TerminalSessionContext) (*RPCSessionResult, error)
+		wantToken string
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			fakeFunc: func(ctx context.Context, tsCtx TerminalSessionContext) (*RPCSessionResult, error) {
+				return &RPCSessionResult{SDKRPCSessionToken: "sess_123"}, nil
+			},
+			wantToken: "sess_123",
+		},
+		{
+			name: "error",
+			fakeFunc: func(ctx context.Context, tsCtx TerminalSessionContext) (*RPCSessionResult, error) {
+				return nil, errors.New("boom")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tsCtx := TerminalSessionContext{API: &FakeStripeAPI{StartNewRPCSessionFunc: tt.fakeFunc}}
+
+			result, err := StartNewRPCSession(tsCtx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StartNewRPCSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if result.SDKRPCSessionToken != tt.wantToken {
+				t.Fatalf("got token %q, want %q", result.SDKRPCSessionToken, tt.wantToken)
+			}
+		})
+	}
+}
+
+func TestCapturePaymentIntent_WithFake(t *testing.T) {
+	tests := []struct {
+		name     string
+		fakeFunc func(ctx context.Context, paymentIntentID string) (*APIResponse, error)
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			fakeFunc: func(ctx context.Context, paymentIntentID string) (*APIResponse, error) {
+				if paymentIntentID != "pi_123" {
+					t.Fatalf("got paymentIntentID %q, want pi_123", paymentIntentID)
+				}
+
+				return &APIResponse{StatusCode: 200}, nil
+			},
+		},
+		{
+			name: "capture failed",
+			fakeFunc: func(ctx context.Context, paymentIntentID string) (*APIResponse, error) {
+				return nil, ErrCapturePaymentIntentFailed
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tsCtx := TerminalSessionContext{
+				PaymentIntentID: "pi_123",
+				API:             &FakeStripeAPI{CapturePaymentIntentFunc: tt.fakeFunc},
+			}
+
+			_, err := CapturePaymentIntent(tsCtx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CapturePaymentIntent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, ErrCapturePaymentIntentFailed) {
+				t.Fatalf("expected ErrCapturePaymentIntentFailed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRegisterReader_WithFake(t *testing.T) {
+	fake := &FakeStripeAPI{
+		RegisterReaderFunc: func(ctx context.Context, regcode string) (*RegisterReaderResult, error) {
+			if regcode != "puppies-plug-earn" {
+				t.Fatalf("got regcode %q, want puppies-plug-earn", regcode)
+			}
+
+			return &RegisterReaderResult{IPAddress: "192.0.2.1"}, nil
+		},
+	}
+
+	result, err := RegisterReader("puppies-plug-earn", TerminalSessionContext{API: fake})
+	if err != nil {
+		t.Fatalf("RegisterReader() error = %v", err)
+	}
+
+	if result.IPAddress != "192.0.2.1" {
+		t.Fatalf("got IPAddress %q, want 192.0.2.1", result.IPAddress)
+	}
+}
@@ -0,0 +1,229 @@
+// Package smartreader implements the terminal.Reader driver for Stripe's
+// server-driven Terminal readers (WisePOS E and BBPOS WisePad 3), which are
+// controlled through the /v1/terminal/readers/:id/process_payment_intent
+// and /cancel_action endpoints rather than the P400's client-driven RPC
+// session flow.
+package smartreader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/terminal"
+)
+
+const (
+	readersPath              = "/v1/terminal/readers"
+	processPaymentIntentPath = "/v1/terminal/readers/%s/process_payment_intent"
+	cancelActionPath         = "/v1/terminal/readers/%s/cancel_action"
+	registerPath             = "/v1/terminal/readers/register"
+)
+
+// deviceTypes are the device_type values Stripe reports for the readers
+// this package drives.
+var deviceTypes = []string{"stripe_wisepos_e", "bbpos_wisepad3"}
+
+func init() {
+	for _, deviceType := range deviceTypes {
+		deviceType := deviceType
+		terminal.Register(deviceType, func(apiKey, baseURL string) terminal.Reader {
+			return &Driver{APIKey: apiKey, BaseURL: baseURL, DeviceType: deviceType}
+		})
+	}
+}
+
+type readersResponse struct {
+	HasMore bool `json:"has_more"`
+	Data    []struct {
+		ID         string `json:"id"`
+		DeviceType string `json:"device_type"`
+		Status     string `json:"status"`
+		IPAddress  string `json:"ip_address"`
+	} `json:"data"`
+}
+
+type registerReaderResponse struct {
+	IPAddress string `json:"ip_address"`
+}
+
+// Driver implements terminal.Reader for Stripe's server-driven Terminal
+// readers. APIKey must be set before use; an empty BaseURL defaults to
+// stripe.DefaultAPIBaseURL.
+type Driver struct {
+	APIKey     string
+	BaseURL    string
+	DeviceType string
+
+	readerID string
+}
+
+// NewDriver returns a Driver that authenticates with apiKey. baseURL may be
+// left empty to use stripe.DefaultAPIBaseURL.
+func NewDriver(apiKey, baseURL string) *Driver {
+	return &Driver{APIKey: apiKey, BaseURL: baseURL}
+}
+
+func (d *Driver) client() (*stripe.Client, error) {
+	baseURL := d.BaseURL
+	if baseURL == "" {
+		baseURL = stripe.DefaultAPIBaseURL
+	}
+
+	parsedBaseURL, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stripe.Client{BaseURL: parsedBaseURL, APIKey: d.APIKey, Verbose: false}, nil
+}
+
+// Discover lists the readers on the account matching this driver's
+// DeviceType, or readers of every device type when DeviceType is empty (as
+// it is for a Driver built by NewDriver). Only the first page of the list
+// is returned; has_more is not followed.
+func (d *Driver) Discover(ctx context.Context) ([]terminal.DiscoveredReader, error) {
+	client, err := d.client()
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if d.DeviceType != "" {
+		query.Set("device_type", d.DeviceType)
+	}
+
+	path := readersPath
+	if len(query) > 0 {
+		path = fmt.Sprintf("%s?%s", path, query.Encode())
+	}
+
+	res, err := client.PerformRequest(ctx, http.MethodGet, path, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("smartreader: discover readers failed with status %d", res.StatusCode)
+	}
+
+	var result readersResponse
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	readers := make([]terminal.DiscoveredReader, 0, len(result.Data))
+	for _, r := range result.Data {
+		readers = append(readers, terminal.DiscoveredReader{
+			ID:         r.ID,
+			DeviceType: r.DeviceType,
+			Status:     r.Status,
+			IPAddress:  r.IPAddress,
+		})
+	}
+
+	return readers, nil
+}
+
+// Connect records readerID as the target of subsequent calls. Unlike the
+// P400's RPC session flow, server-driven readers don't require a separate
+// handshake before processing a payment.
+func (d *Driver) Connect(ctx context.Context, readerID string) error {
+	d.readerID = readerID
+	return nil
+}
+
+// CollectPaymentMethod is a no-op for server-driven readers: ProcessPayment
+// both collects the payment method and processes it in a single API call.
+func (d *Driver) CollectPaymentMethod(ctx context.Context, paymentIntentID string) error {
+	return nil
+}
+
+// ProcessPayment tells the connected reader to collect a payment method and
+// process it against paymentIntentID via process_payment_intent.
+func (d *Driver) ProcessPayment(ctx context.Context, paymentIntentID string) error {
+	if d.readerID == "" {
+		return fmt.Errorf("smartreader: Connect must be called before ProcessPayment")
+	}
+
+	client, err := d.client()
+	if err != nil {
+		return err
+	}
+
+	data := url.Values{}
+	data.Set("payment_intent", paymentIntentID)
+
+	path := fmt.Sprintf(processPaymentIntentPath, d.readerID)
+
+	res, err := client.PerformRequest(ctx, http.MethodPost, path, data.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("smartreader: process payment intent failed with status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// Cancel cancels the connected reader's current action via cancel_action.
+func (d *Driver) Cancel(ctx context.Context) error {
+	if d.readerID == "" {
+		return fmt.Errorf("smartreader: Connect must be called before Cancel")
+	}
+
+	client, err := d.client()
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf(cancelActionPath, d.readerID)
+
+	res, err := client.PerformRequest(ctx, http.MethodPost, path, "", nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("smartreader: cancel action failed with status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// Register registers a new reader to the account using regCode, returning
+// its IP address.
+func (d *Driver) Register(ctx context.Context, regCode string) (string, error) {
+	client, err := d.client()
+	if err != nil {
+		return "", err
+	}
+
+	data := url.Values{}
+	data.Set("registration_code", regCode)
+
+	res, err := client.PerformRequest(ctx, http.MethodPost, registerPath, data.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("smartreader: register reader failed with status %d", res.StatusCode)
+	}
+
+	var result registerReaderResponse
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", err
+	}
+
+	return result.IPAddress, nil
+}